@@ -0,0 +1,100 @@
+package main
+
+import (
+    "flag"
+    "fmt"
+    "os"
+    "time"
+)
+
+// Config holds the service's runtime configuration, assembled from flags
+// with environment variables as defaults.
+type Config struct {
+    ListenAddr         string
+    InternalListenAddr string
+    TLSCert            string
+    TLSKey             string
+    ShutdownTimeout    time.Duration
+    LogLevel           string
+
+    // ConsulAddr is the address of a Consul agent to register with
+    // (e.g. "http://127.0.0.1:8500"). Set via the CONSUL_ADDR
+    // environment variable; registration is skipped when empty.
+    ConsulAddr string
+}
+
+// loadConfig parses flags (falling back to environment variables for
+// their defaults) into a Config and validates it. args is normally
+// os.Args[1:].
+func loadConfig(args []string) (Config, error) {
+    fs := flag.NewFlagSet("go_service", flag.ContinueOnError)
+
+    listenAddr := fs.String("listen-addr", envOrDefault("LISTEN_ADDR", publicAddr), "address the public server listens on")
+    internalListenAddr := fs.String("internal-listen-addr", envOrDefault("INTERNAL_LISTEN_ADDR", internalAddr), "address the internal admin server listens on")
+    tlsCert := fs.String("tls-cert", os.Getenv("TLS_CERT"), "path to a TLS certificate; enables TLS on the public server when set with -tls-key")
+    tlsKey := fs.String("tls-key", os.Getenv("TLS_KEY"), "path to a TLS private key; enables TLS on the public server when set with -tls-cert")
+    shutdownTimeoutStr := fs.String("shutdown-timeout", envOrDefault("SHUTDOWN_TIMEOUT", shutdownTimeout.String()), "how long to wait for in-flight requests to drain on shutdown")
+    logLevel := fs.String("log-level", envOrDefault("LOG_LEVEL", "info"), "minimum log level: debug, info, warn, or error")
+
+    if err := fs.Parse(args); err != nil {
+        return Config{}, err
+    }
+
+    parsedShutdownTimeout, err := time.ParseDuration(*shutdownTimeoutStr)
+    if err != nil {
+        return Config{}, fmt.Errorf("invalid -shutdown-timeout %q: %w", *shutdownTimeoutStr, err)
+    }
+
+    cfg := Config{
+        ListenAddr:         *listenAddr,
+        InternalListenAddr: *internalListenAddr,
+        TLSCert:            *tlsCert,
+        TLSKey:             *tlsKey,
+        ShutdownTimeout:    parsedShutdownTimeout,
+        LogLevel:           *logLevel,
+        ConsulAddr:         os.Getenv("CONSUL_ADDR"),
+    }
+
+    if err := cfg.validate(); err != nil {
+        return Config{}, err
+    }
+    return cfg, nil
+}
+
+// validate rejects configurations that would fail confusingly later, such
+// as an incomplete TLS pair or an unrecognised log level.
+func (c Config) validate() error {
+    if c.ListenAddr == "" {
+        return fmt.Errorf("listen address must not be empty")
+    }
+    if c.InternalListenAddr == "" {
+        return fmt.Errorf("internal listen address must not be empty")
+    }
+    if c.ListenAddr == c.InternalListenAddr {
+        return fmt.Errorf("listen address and internal listen address must differ")
+    }
+    if (c.TLSCert == "") != (c.TLSKey == "") {
+        return fmt.Errorf("tls-cert and tls-key must both be set or both be empty")
+    }
+    if c.ShutdownTimeout <= 0 {
+        return fmt.Errorf("shutdown timeout must be positive, got %s", c.ShutdownTimeout)
+    }
+    switch c.LogLevel {
+    case "debug", "info", "warn", "error":
+    default:
+        return fmt.Errorf("invalid log level %q: must be debug, info, warn, or error", c.LogLevel)
+    }
+    return nil
+}
+
+// TLSEnabled reports whether the public server should serve TLS.
+func (c Config) TLSEnabled() bool {
+    return c.TLSCert != "" && c.TLSKey != ""
+}
+
+func envOrDefault(key, def string) string {
+    if v, ok := os.LookupEnv(key); ok {
+        return v
+    }
+    return def
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+func TestConfigValidate(t *testing.T) {
+    base := func() Config {
+        return Config{
+            ListenAddr:         ":4000",
+            InternalListenAddr: ":4001",
+            ShutdownTimeout:    15 * time.Second,
+            LogLevel:           "info",
+        }
+    }
+
+    tests := []struct {
+        name    string
+        mutate  func(c *Config)
+        wantErr bool
+    }{
+        {"valid default", func(c *Config) {}, false},
+        {"empty listen addr", func(c *Config) { c.ListenAddr = "" }, true},
+        {"empty internal listen addr", func(c *Config) { c.InternalListenAddr = "" }, true},
+        {"listen addrs collide", func(c *Config) { c.InternalListenAddr = c.ListenAddr }, true},
+        {"tls cert without key", func(c *Config) { c.TLSCert = "cert.pem" }, true},
+        {"tls key without cert", func(c *Config) { c.TLSKey = "key.pem" }, true},
+        {"tls cert and key set", func(c *Config) { c.TLSCert = "cert.pem"; c.TLSKey = "key.pem" }, false},
+        {"zero shutdown timeout", func(c *Config) { c.ShutdownTimeout = 0 }, true},
+        {"negative shutdown timeout", func(c *Config) { c.ShutdownTimeout = -time.Second }, true},
+        {"invalid log level", func(c *Config) { c.LogLevel = "verbose" }, true},
+        {"debug log level", func(c *Config) { c.LogLevel = "debug" }, false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            cfg := base()
+            tt.mutate(&cfg)
+            err := cfg.validate()
+            if (err != nil) != tt.wantErr {
+                t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+            }
+        })
+    }
+}
+
+func TestConfigTLSEnabled(t *testing.T) {
+    cfg := Config{TLSCert: "cert.pem", TLSKey: "key.pem"}
+    if !cfg.TLSEnabled() {
+        t.Error("TLSEnabled() = false, want true when both cert and key are set")
+    }
+
+    cfg = Config{}
+    if cfg.TLSEnabled() {
+        t.Error("TLSEnabled() = true, want false when neither cert nor key is set")
+    }
+}
+
+func TestEnvOrDefault(t *testing.T) {
+    const key = "GO_SERVICE_TEST_ENV_OR_DEFAULT"
+
+    if got := envOrDefault(key, "fallback"); got != "fallback" {
+        t.Errorf("envOrDefault() = %q, want %q when unset", got, "fallback")
+    }
+
+    t.Setenv(key, "from-env")
+    if got := envOrDefault(key, "fallback"); got != "from-env" {
+        t.Errorf("envOrDefault() = %q, want %q when set", got, "from-env")
+    }
+}
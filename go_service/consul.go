@@ -0,0 +1,107 @@
+package main
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "os"
+)
+
+const (
+    consulServiceName = "vots-worker"
+    consulTimeout     = "5s"
+    consulInterval    = "10s"
+)
+
+// ConsulRegistrar registers this service with a Consul agent so the rest
+// of the VOTS orchestration can discover it without hardcoded addresses.
+// It is a no-op when addr is empty, which keeps local/dev runs unaffected.
+type ConsulRegistrar struct {
+    addr       string
+    httpClient *http.Client
+    serviceID  string
+}
+
+// NewConsulRegistrar returns a registrar talking to the Consul agent at
+// addr (e.g. "http://127.0.0.1:8500"). Pass an empty addr to disable it.
+func NewConsulRegistrar(addr string) *ConsulRegistrar {
+    return &ConsulRegistrar{
+        addr:       addr,
+        httpClient: &http.Client{},
+        serviceID:  fmt.Sprintf("%s-%d", consulServiceName, os.Getpid()),
+    }
+}
+
+// Enabled reports whether a Consul agent address was configured.
+func (c *ConsulRegistrar) Enabled() bool {
+    return c.addr != ""
+}
+
+type consulServiceRegistration struct {
+    ID      string       `json:"ID"`
+    Name    string       `json:"Name"`
+    Address string       `json:"Address"`
+    Port    int          `json:"Port"`
+    Tags    []string     `json:"Tags"`
+    Check   *consulCheck `json:"Check,omitempty"`
+}
+
+type consulCheck struct {
+    HTTP     string `json:"HTTP"`
+    Interval string `json:"Interval"`
+    Timeout  string `json:"Timeout"`
+}
+
+// Register registers the service with Consul, advertising advertiseHost
+// and advertisePort as its address and pointing the health check at
+// healthURL (expected to serve /health on the internal listener).
+func (c *ConsulRegistrar) Register(ctx context.Context, advertiseHost string, advertisePort int, healthURL string) error {
+    reg := consulServiceRegistration{
+        ID:      c.serviceID,
+        Name:    consulServiceName,
+        Address: advertiseHost,
+        Port:    advertisePort,
+        Tags:    []string{"http", "networking", "vots-worker"},
+        Check: &consulCheck{
+            HTTP:     healthURL,
+            Interval: consulInterval,
+            Timeout:  consulTimeout,
+        },
+    }
+
+    body, err := json.Marshal(reg)
+    if err != nil {
+        return fmt.Errorf("marshal consul registration: %w", err)
+    }
+
+    return c.do(ctx, http.MethodPut, "/v1/agent/service/register", body)
+}
+
+// Deregister removes the service registration from Consul. It should be
+// called before the server's Shutdown so Consul stops routing traffic
+// here before connections actually drain.
+func (c *ConsulRegistrar) Deregister(ctx context.Context) error {
+    path := fmt.Sprintf("/v1/agent/service/deregister/%s", c.serviceID)
+    return c.do(ctx, http.MethodPut, path, nil)
+}
+
+func (c *ConsulRegistrar) do(ctx context.Context, method, path string, body []byte) error {
+    req, err := http.NewRequestWithContext(ctx, method, c.addr+path, bytes.NewReader(body))
+    if err != nil {
+        return fmt.Errorf("build consul request: %w", err)
+    }
+    req.Header.Set("Content-Type", "application/json")
+
+    resp, err := c.httpClient.Do(req)
+    if err != nil {
+        return fmt.Errorf("consul request to %s: %w", path, err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode >= 300 {
+        return fmt.Errorf("consul request to %s: unexpected status %s", path, resp.Status)
+    }
+    return nil
+}
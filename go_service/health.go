@@ -0,0 +1,70 @@
+package main
+
+import (
+    "net/http"
+    "sync/atomic"
+)
+
+// Health reports whether the process itself is alive. Once set to
+// unhealthy it is not expected to recover; callers should restart the
+// process.
+type Health struct {
+    ok atomic.Bool
+}
+
+// NewHealth returns a Health that starts out healthy.
+func NewHealth() *Health {
+    h := &Health{}
+    h.ok.Store(true)
+    return h
+}
+
+// Set updates the health state.
+func (h *Health) Set(ok bool) {
+    h.ok.Store(ok)
+}
+
+// Handler serves the current health state: 200 when healthy, 503 otherwise.
+func (h *Health) Handler() http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        if h.ok.Load() {
+            w.WriteHeader(http.StatusOK)
+            w.Write([]byte("OK\n"))
+            return
+        }
+        w.WriteHeader(http.StatusServiceUnavailable)
+        w.Write([]byte("UNHEALTHY\n"))
+    }
+}
+
+// Readiness reports whether the service should currently receive traffic.
+// Unlike Health it is expected to flip during the service's lifetime,
+// e.g. to false while draining connections during shutdown.
+type Readiness struct {
+    ready atomic.Bool
+}
+
+// NewReadiness returns a Readiness that starts out ready.
+func NewReadiness() *Readiness {
+    r := &Readiness{}
+    r.ready.Store(true)
+    return r
+}
+
+// Set updates the readiness state.
+func (r *Readiness) Set(ready bool) {
+    r.ready.Store(ready)
+}
+
+// Handler serves the current readiness state: 200 when ready, 503 otherwise.
+func (r *Readiness) Handler() http.HandlerFunc {
+    return func(w http.ResponseWriter, req *http.Request) {
+        if r.ready.Load() {
+            w.WriteHeader(http.StatusOK)
+            w.Write([]byte("READY\n"))
+            return
+        }
+        w.WriteHeader(http.StatusServiceUnavailable)
+        w.Write([]byte("NOT READY\n"))
+    }
+}
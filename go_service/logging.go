@@ -0,0 +1,92 @@
+package main
+
+import (
+    "fmt"
+    "io"
+    "os"
+    "strings"
+    "time"
+)
+
+// logLevel is an ordinal severity used to gate log output.
+type logLevel int
+
+const (
+    levelDebug logLevel = iota
+    levelInfo
+    levelWarn
+    levelError
+)
+
+func parseLogLevel(s string) logLevel {
+    switch s {
+    case "debug":
+        return levelDebug
+    case "warn":
+        return levelWarn
+    case "error":
+        return levelError
+    default:
+        return levelInfo
+    }
+}
+
+func (l logLevel) String() string {
+    switch l {
+    case levelDebug:
+        return "debug"
+    case levelWarn:
+        return "warn"
+    case levelError:
+        return "error"
+    default:
+        return "info"
+    }
+}
+
+// Logger emits logfmt-style lines, dropping anything below its configured
+// level.
+type Logger struct {
+    out   io.Writer
+    level logLevel
+}
+
+// NewLogger returns a Logger writing to os.Stderr that only emits
+// messages at or above level.
+func NewLogger(level string) *Logger {
+    return &Logger{out: os.Stderr, level: parseLogLevel(level)}
+}
+
+func (l *Logger) log(level logLevel, msg string, kv ...any) {
+    if level < l.level {
+        return
+    }
+    line := fmt.Sprintf("ts=%s level=%s msg=%q", time.Now().UTC().Format(time.RFC3339), level, msg)
+    for i := 0; i+1 < len(kv); i += 2 {
+        line += fmt.Sprintf(" %v=%s", kv[i], logfmtValue(kv[i+1]))
+    }
+    fmt.Fprintln(l.out, line)
+}
+
+// logfmtValue renders v as a logfmt field value, quoting it with %q when
+// it contains whitespace, '=', or '"' so downstream parsers can't
+// misread it as the start of the next field.
+func logfmtValue(v any) string {
+    s := fmt.Sprintf("%v", v)
+    if strings.ContainsAny(s, " \t\"=") {
+        return fmt.Sprintf("%q", s)
+    }
+    return s
+}
+
+// Debug logs at debug level with alternating key/value pairs.
+func (l *Logger) Debug(msg string, kv ...any) { l.log(levelDebug, msg, kv...) }
+
+// Info logs at info level with alternating key/value pairs.
+func (l *Logger) Info(msg string, kv ...any) { l.log(levelInfo, msg, kv...) }
+
+// Warn logs at warn level with alternating key/value pairs.
+func (l *Logger) Warn(msg string, kv ...any) { l.log(levelWarn, msg, kv...) }
+
+// Error logs at error level with alternating key/value pairs.
+func (l *Logger) Error(msg string, kv ...any) { l.log(levelError, msg, kv...) }
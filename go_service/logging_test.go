@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestLogfmtValue(t *testing.T) {
+    tests := []struct {
+        name string
+        v    any
+        want string
+    }{
+        {"plain word", "tcp_ping", "tcp_ping"},
+        {"integer", 42, "42"},
+        {"boolean", true, "true"},
+        {"contains space", "dial tcp: connection refused", `"dial tcp: connection refused"`},
+        {"contains equals", "key=value", `"key=value"`},
+        {"contains quote", `say "hi"`, `"say \"hi\""`},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := logfmtValue(tt.v); got != tt.want {
+                t.Errorf("logfmtValue(%v) = %q, want %q", tt.v, got, tt.want)
+            }
+        })
+    }
+}
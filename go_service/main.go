@@ -1,34 +1,146 @@
 package main
 
 import (
+    "context"
     "fmt"
-    "log"
+    "net"
     "net/http"
     "os"
     "os/signal"
+    "strconv"
+    "sync"
     "syscall"
+    "time"
+)
+
+// Defaults used when neither a flag nor the corresponding environment
+// variable is set.
+const (
+    publicAddr      = ":4000"
+    internalAddr    = ":4001"
+    shutdownTimeout = 15 * time.Second
 )
 
 func main() {
-    http.HandleFunc("/go_task", func(w http.ResponseWriter, r *http.Request) {
-        fmt.Fprintln(w, "Hello from Go Service! (Networking tasks)")
-    })
-
-    http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-        fmt.Fprintln(w, "OK")
-    })
-
-    srv := &http.Server{Addr: ":4000"}
-    go func() {
-        log.Println("Go Service => :4000 (Use /health)")
-        if err := srv.ListenAndServe(); err != http.ErrServerClosed {
-            log.Fatalf("ListenAndServe(): %v", err)
+    cfg, err := loadConfig(os.Args[1:])
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "invalid configuration: %v\n", err)
+        os.Exit(1)
+    }
+
+    logger := NewLogger(cfg.LogLevel)
+
+    health := NewHealth()
+    ready := NewReadiness()
+    metrics := NewMetrics()
+
+    registry := NewTaskRegistry()
+    registerBuiltinTasks(registry)
+
+    // shutdownBaseCtx is handed to every in-flight request as its base
+    // context. It is cancelled once shutdown begins (see below) so a
+    // handler that checks ctx.Done() can abort early instead of running
+    // to completion, while Shutdown itself still waits for it to return.
+    shutdownBaseCtx, cancelShutdownBaseCtx := context.WithCancel(context.Background())
+    defer cancelShutdownBaseCtx()
+
+    publicSrv := newPublicServer(cfg.ListenAddr, registry, metrics, logger, shutdownBaseCtx)
+    internalSrv := newInternalServer(cfg.InternalListenAddr, health, ready, metrics, shutdownBaseCtx)
+
+    var wg sync.WaitGroup
+    wg.Add(2)
+    go runServer(&wg, logger, "public", publicSrv, cfg.TLSCert, cfg.TLSKey)
+    go runServer(&wg, logger, "internal", internalSrv, "", "")
+
+    consul := NewConsulRegistrar(cfg.ConsulAddr)
+    if consul.Enabled() {
+        registerCtx, registerCancel := context.WithTimeout(context.Background(), consulTimeoutDuration)
+        advertiseHost, advertisePort := advertiseHostPort(cfg.ListenAddr)
+        healthURL := "http://" + advertiseHealthHost(cfg.InternalListenAddr) + "/health"
+        if err := consul.Register(registerCtx, advertiseHost, advertisePort, healthURL); err != nil {
+            logger.Error("consul registration failed", "err", err)
+        } else {
+            logger.Info("registered with consul", "addr", cfg.ConsulAddr)
         }
-    }()
+        registerCancel()
+    }
 
     quit := make(chan os.Signal, 1)
     signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
     <-quit
-    log.Println("Shutting down Go Service gracefully...")
-    srv.Shutdown(nil)
+    logger.Info("shutting down gracefully")
+
+    // Mark not-ready first so load balancers stop sending new traffic
+    // while in-flight requests drain.
+    ready.Set(false)
+
+    // Let in-flight handlers observe shutdown now that draining has
+    // begun, so long-running ones can abort early instead of running
+    // until they finish on their own.
+    cancelShutdownBaseCtx()
+
+    ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+    defer cancel()
+
+    if consul.Enabled() {
+        if err := consul.Deregister(ctx); err != nil {
+            logger.Error("consul deregistration failed", "err", err)
+        }
+    }
+
+    for _, srv := range []*http.Server{publicSrv, internalSrv} {
+        if err := srv.Shutdown(ctx); err != nil {
+            logger.Error("graceful shutdown failed", "addr", srv.Addr, "err", err)
+        }
+    }
+
+    wg.Wait()
+}
+
+const consulTimeoutDuration = 5 * time.Second
+
+// advertiseHostPort splits a listen address like ":4000" or
+// "0.0.0.0:4000" into the host Consul should advertise (falling back to
+// the machine hostname for unspecified hosts) and the numeric port.
+func advertiseHostPort(listenAddr string) (string, int) {
+    host, portStr, err := net.SplitHostPort(listenAddr)
+    if err != nil {
+        return "", 0
+    }
+    port, err := strconv.Atoi(portStr)
+    if err != nil {
+        return "", 0
+    }
+    if host == "" || host == "0.0.0.0" {
+        if hostname, err := os.Hostname(); err == nil {
+            host = hostname
+        }
+    }
+    return host, port
+}
+
+// advertiseHealthHost returns the host:port the Consul health check
+// should probe for the internal listener.
+func advertiseHealthHost(internalListenAddr string) string {
+    host, port := advertiseHostPort(internalListenAddr)
+    return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// runServer runs srv until it is shut down, serving TLS when both
+// tlsCert and tlsKey are non-empty.
+func runServer(wg *sync.WaitGroup, logger *Logger, name string, srv *http.Server, tlsCert, tlsKey string) {
+    defer wg.Done()
+
+    var err error
+    if tlsCert != "" && tlsKey != "" {
+        logger.Info("server listening", "server", name, "addr", srv.Addr, "tls", true)
+        err = srv.ListenAndServeTLS(tlsCert, tlsKey)
+    } else {
+        logger.Info("server listening", "server", name, "addr", srv.Addr, "tls", false)
+        err = srv.ListenAndServe()
+    }
+    if err != nil && err != http.ErrServerClosed {
+        logger.Error("server failed", "server", name, "err", err)
+        os.Exit(1)
+    }
 }
@@ -0,0 +1,34 @@
+package main
+
+import (
+    "os"
+    "testing"
+)
+
+func TestAdvertiseHostPort(t *testing.T) {
+    hostname, err := os.Hostname()
+    if err != nil {
+        t.Skipf("os.Hostname() unavailable: %v", err)
+    }
+
+    tests := []struct {
+        name     string
+        addr     string
+        wantHost string
+        wantPort int
+    }{
+        {"unspecified host", ":4000", hostname, 4000},
+        {"explicit zero address", "0.0.0.0:4001", hostname, 4001},
+        {"explicit host", "worker.internal:4000", "worker.internal", 4000},
+        {"malformed addr", "not-an-addr", "", 0},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            host, port := advertiseHostPort(tt.addr)
+            if host != tt.wantHost || port != tt.wantPort {
+                t.Errorf("advertiseHostPort(%q) = (%q, %d), want (%q, %d)", tt.addr, host, port, tt.wantHost, tt.wantPort)
+            }
+        })
+    }
+}
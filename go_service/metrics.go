@@ -0,0 +1,33 @@
+package main
+
+import (
+    "fmt"
+    "net/http"
+    "sync/atomic"
+)
+
+// Metrics holds simple in-process counters exposed in Prometheus text
+// exposition format on the internal listener.
+type Metrics struct {
+    goTaskRequests atomic.Int64
+}
+
+// NewMetrics returns a Metrics with all counters zeroed.
+func NewMetrics() *Metrics {
+    return &Metrics{}
+}
+
+// IncGoTaskRequests records one request served by /go_task.
+func (m *Metrics) IncGoTaskRequests() {
+    m.goTaskRequests.Add(1)
+}
+
+// Handler serves the current counters in Prometheus text exposition format.
+func (m *Metrics) Handler() http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+        fmt.Fprintf(w, "# HELP go_task_requests_total Total requests served by /go_task.\n")
+        fmt.Fprintf(w, "# TYPE go_task_requests_total counter\n")
+        fmt.Fprintf(w, "go_task_requests_total %d\n", m.goTaskRequests.Load())
+    }
+}
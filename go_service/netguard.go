@@ -0,0 +1,53 @@
+package main
+
+import (
+    "context"
+    "fmt"
+    "net"
+)
+
+// resolveAndGuard resolves host and rejects it if it (or any of its
+// resolved addresses) points at loopback, link-local, unspecified, or
+// private address space. This keeps the unauthenticated /go_task
+// dispatcher from being used to probe or port-scan internal
+// infrastructure (e.g. cloud metadata endpoints) from the public
+// listener.
+func resolveAndGuard(ctx context.Context, host string) ([]net.IP, error) {
+    ipAddrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+    if err != nil {
+        return nil, fmt.Errorf("resolve %s: %w", host, err)
+    }
+    if len(ipAddrs) == 0 {
+        return nil, fmt.Errorf("resolve %s: no addresses found", host)
+    }
+
+    ips := make([]net.IP, 0, len(ipAddrs))
+    for _, ipAddr := range ipAddrs {
+        if isGuardedIP(ipAddr.IP) {
+            return nil, fmt.Errorf("target %s resolves to disallowed address %s", host, ipAddr.IP)
+        }
+        ips = append(ips, ipAddr.IP)
+    }
+    return ips, nil
+}
+
+// isGuardedIP reports whether ip falls in address space that the public,
+// unauthenticated networking tasks must not be allowed to reach.
+func isGuardedIP(ip net.IP) bool {
+    return ip.IsLoopback() ||
+        ip.IsUnspecified() ||
+        ip.IsLinkLocalUnicast() ||
+        ip.IsLinkLocalMulticast() ||
+        ip.IsPrivate() ||
+        ip.IsMulticast()
+}
+
+// looksLikeHostOrIP rejects strings that would be parsed as flags by a
+// shelled-out command (e.g. a target starting with "-") instead of as a
+// hostname or IP address.
+func looksLikeHostOrIP(target string) bool {
+    if target == "" || target[0] == '-' {
+        return false
+    }
+    return true
+}
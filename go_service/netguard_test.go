@@ -0,0 +1,80 @@
+package main
+
+import (
+    "context"
+    "net"
+    "testing"
+)
+
+func TestIsGuardedIP(t *testing.T) {
+    tests := []struct {
+        name string
+        ip   string
+        want bool
+    }{
+        {"loopback v4", "127.0.0.1", true},
+        {"loopback v6", "::1", true},
+        {"unspecified v4", "0.0.0.0", true},
+        {"link-local metadata endpoint", "169.254.169.254", true},
+        {"link-local v6", "fe80::1", true},
+        {"private 10/8", "10.0.0.1", true},
+        {"private 172.16/12", "172.16.0.1", true},
+        {"private 192.168/16", "192.168.1.1", true},
+        {"multicast", "224.0.0.1", true},
+        {"public v4", "8.8.8.8", false},
+        {"public v6", "2001:4860:4860::8888", false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            ip := net.ParseIP(tt.ip)
+            if ip == nil {
+                t.Fatalf("net.ParseIP(%q) returned nil", tt.ip)
+            }
+            if got := isGuardedIP(ip); got != tt.want {
+                t.Errorf("isGuardedIP(%s) = %v, want %v", tt.ip, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestResolveAndGuard(t *testing.T) {
+    tests := []struct {
+        name    string
+        host    string
+        wantErr bool
+    }{
+        {"loopback literal rejected", "127.0.0.1", true},
+        {"metadata literal rejected", "169.254.169.254", true},
+        {"private literal rejected", "10.1.2.3", true},
+        {"public literal allowed", "8.8.8.8", false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            _, err := resolveAndGuard(context.Background(), tt.host)
+            if (err != nil) != tt.wantErr {
+                t.Errorf("resolveAndGuard(%q) error = %v, wantErr %v", tt.host, err, tt.wantErr)
+            }
+        })
+    }
+}
+
+func TestLooksLikeHostOrIP(t *testing.T) {
+    tests := []struct {
+        target string
+        want   bool
+    }{
+        {"example.com", true},
+        {"8.8.8.8", true},
+        {"", false},
+        {"-oProxyCommand=whoami", false},
+        {"--help", false},
+    }
+
+    for _, tt := range tests {
+        if got := looksLikeHostOrIP(tt.target); got != tt.want {
+            t.Errorf("looksLikeHostOrIP(%q) = %v, want %v", tt.target, got, tt.want)
+        }
+    }
+}
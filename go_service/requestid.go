@@ -0,0 +1,45 @@
+package main
+
+import (
+    "context"
+    "crypto/rand"
+    "encoding/hex"
+    "net/http"
+)
+
+type contextKey int
+
+const requestIDKey contextKey = 0
+
+// requestIDHeader is the header checked for a caller-supplied request ID
+// and used to echo it back.
+const requestIDHeader = "X-Request-Id"
+
+// requestIDFromContext returns the request ID stored in ctx, or "" if none.
+func requestIDFromContext(ctx context.Context) string {
+    id, _ := ctx.Value(requestIDKey).(string)
+    return id
+}
+
+// withRequestID wraps next so every request has a request ID in its
+// context and response, reusing the caller's X-Request-Id header when
+// present.
+func withRequestID(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        id := r.Header.Get(requestIDHeader)
+        if id == "" {
+            id = newRequestID()
+        }
+        w.Header().Set(requestIDHeader, id)
+        ctx := context.WithValue(r.Context(), requestIDKey, id)
+        next.ServeHTTP(w, r.WithContext(ctx))
+    })
+}
+
+func newRequestID() string {
+    var b [8]byte
+    if _, err := rand.Read(b[:]); err != nil {
+        return "unknown"
+    }
+    return hex.EncodeToString(b[:])
+}
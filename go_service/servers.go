@@ -0,0 +1,65 @@
+package main
+
+import (
+    "context"
+    "net"
+    "net/http"
+    "net/http/pprof"
+    "time"
+)
+
+const (
+    readTimeout  = 5 * time.Second
+    writeTimeout = 10 * time.Second
+    idleTimeout  = 120 * time.Second
+)
+
+// baseContext returns the http.Server.BaseContext func that hands every
+// incoming request shutdownCtx as its base context, so a handler that
+// checks ctx.Done() can observe and abort early when shutdown begins,
+// instead of running until ReadTimeout/WriteTimeout or completion.
+func baseContext(shutdownCtx context.Context) func(net.Listener) context.Context {
+    return func(_ net.Listener) context.Context {
+        return shutdownCtx
+    }
+}
+
+// newPublicServer builds the server that handles client-facing traffic.
+func newPublicServer(addr string, registry *TaskRegistry, metrics *Metrics, logger *Logger, shutdownCtx context.Context) *http.Server {
+    mux := http.NewServeMux()
+    mux.HandleFunc("/go_task/", dispatchHandler(registry, metrics, logger))
+
+    return &http.Server{
+        Addr:         addr,
+        Handler:      withRequestID(mux),
+        ReadTimeout:  readTimeout,
+        WriteTimeout: writeTimeout,
+        IdleTimeout:  idleTimeout,
+        BaseContext:  baseContext(shutdownCtx),
+    }
+}
+
+// newInternalServer builds the admin server exposing health, readiness,
+// metrics, and pprof profiling endpoints. It is never reachable from
+// outside the cluster and should be bound to a private address.
+func newInternalServer(addr string, health *Health, ready *Readiness, metrics *Metrics, shutdownCtx context.Context) *http.Server {
+    mux := http.NewServeMux()
+    mux.HandleFunc("/health", health.Handler())
+    mux.HandleFunc("/ready", ready.Handler())
+    mux.HandleFunc("/metrics", metrics.Handler())
+
+    mux.HandleFunc("/debug/pprof/", pprof.Index)
+    mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+    mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+    mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+    mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+    return &http.Server{
+        Addr:         addr,
+        Handler:      withRequestID(mux),
+        ReadTimeout:  readTimeout,
+        WriteTimeout: writeTimeout,
+        IdleTimeout:  idleTimeout,
+        BaseContext:  baseContext(shutdownCtx),
+    }
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "strings"
+    "sync"
+    "time"
+)
+
+// defaultTaskTimeout bounds task execution when the request body does not
+// specify one.
+const defaultTaskTimeout = 5 * time.Second
+
+// maxTaskTimeout caps the client-supplied timeout_ms so a caller can't
+// park a handler goroutine (and its outbound dial) indefinitely against
+// a routable-but-black-holed target.
+const maxTaskTimeout = 30 * time.Second
+
+// TaskHandler runs a single named networking task against target and
+// returns a JSON-serialisable result.
+type TaskHandler func(ctx context.Context, target string) (any, error)
+
+// TaskRegistry maps task names to their handlers.
+type TaskRegistry struct {
+    mu       sync.RWMutex
+    handlers map[string]TaskHandler
+}
+
+// NewTaskRegistry returns an empty TaskRegistry.
+func NewTaskRegistry() *TaskRegistry {
+    return &TaskRegistry{handlers: make(map[string]TaskHandler)}
+}
+
+// Register adds or replaces the handler for name.
+func (r *TaskRegistry) Register(name string, handler TaskHandler) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.handlers[name] = handler
+}
+
+// Lookup returns the handler registered for name, if any.
+func (r *TaskRegistry) Lookup(name string) (TaskHandler, bool) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    h, ok := r.handlers[name]
+    return h, ok
+}
+
+// taskRequest is the JSON envelope accepted by the dispatcher.
+type taskRequest struct {
+    Target    string `json:"target"`
+    TimeoutMS int    `json:"timeout_ms,omitempty"`
+}
+
+// taskResponse is the JSON envelope returned by the dispatcher.
+type taskResponse struct {
+    Task       string `json:"task"`
+    Target     string `json:"target"`
+    Success    bool   `json:"success"`
+    DurationMS int64  `json:"duration_ms"`
+    Result     any    `json:"result,omitempty"`
+    Error      string `json:"error,omitempty"`
+}
+
+// dispatchHandler returns the /go_task/{name} handler for registry, also
+// counting every request in metrics and logging its outcome via logger.
+func dispatchHandler(registry *TaskRegistry, metrics *Metrics, logger *Logger) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        metrics.IncGoTaskRequests()
+
+        name := strings.TrimPrefix(r.URL.Path, "/go_task/")
+        if name == "" || name == r.URL.Path {
+            http.Error(w, "task name required: /go_task/{name}", http.StatusBadRequest)
+            return
+        }
+
+        handler, ok := registry.Lookup(name)
+        if !ok {
+            http.Error(w, fmt.Sprintf("unknown task %q", name), http.StatusNotFound)
+            return
+        }
+
+        var req taskRequest
+        if r.Body != nil {
+            defer r.Body.Close()
+            if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err.Error() != "EOF" {
+                http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+                return
+            }
+        }
+        if req.Target == "" {
+            http.Error(w, "target required", http.StatusBadRequest)
+            return
+        }
+
+        timeout := defaultTaskTimeout
+        if req.TimeoutMS > 0 {
+            timeout = time.Duration(req.TimeoutMS) * time.Millisecond
+            if timeout > maxTaskTimeout {
+                timeout = maxTaskTimeout
+            }
+        }
+
+        ctx, cancel := context.WithTimeout(r.Context(), timeout)
+        defer cancel()
+
+        start := time.Now()
+        result, err := handler(ctx, req.Target)
+        resp := taskResponse{
+            Task:       name,
+            Target:     req.Target,
+            Success:    err == nil,
+            DurationMS: time.Since(start).Milliseconds(),
+            Result:     result,
+        }
+        if err != nil {
+            resp.Error = err.Error()
+        }
+
+        logger.Debug("task dispatched",
+            "request_id", requestIDFromContext(r.Context()),
+            "task", name,
+            "target", req.Target,
+            "success", resp.Success,
+            "duration_ms", resp.DurationMS,
+        )
+
+        w.Header().Set("Content-Type", "application/json")
+        if err := json.NewEncoder(w).Encode(resp); err != nil {
+            http.Error(w, err.Error(), http.StatusInternalServerError)
+        }
+    }
+}
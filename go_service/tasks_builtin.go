@@ -0,0 +1,162 @@
+package main
+
+import (
+    "bufio"
+    "bytes"
+    "context"
+    "fmt"
+    "net"
+    "net/http"
+    "net/url"
+    "os/exec"
+    "time"
+)
+
+// registerBuiltinTasks wires the networking task handlers advertised by
+// this service into registry.
+func registerBuiltinTasks(registry *TaskRegistry) {
+    registry.Register("tcp_ping", tcpPingTask)
+    registry.Register("dns_lookup", dnsLookupTask)
+    registry.Register("http_probe", httpProbeTask)
+    registry.Register("traceroute", tracerouteTask)
+}
+
+// tcpPingResult reports the outcome of a TCP dial to target.
+type tcpPingResult struct {
+    LatencyMS int64 `json:"latency_ms"`
+}
+
+// tcpPingTask dials target (host:port) and reports the connect latency.
+// The resolved address is validated against internal/private address
+// space first, since this handler is reachable from the unauthenticated
+// public listener and must not double as an internal port scanner.
+func tcpPingTask(ctx context.Context, target string) (any, error) {
+    host, port, err := net.SplitHostPort(target)
+    if err != nil {
+        return nil, fmt.Errorf("tcp ping %s: %w", target, err)
+    }
+    ips, err := resolveAndGuard(ctx, host)
+    if err != nil {
+        return nil, err
+    }
+
+    var d net.Dialer
+    start := time.Now()
+    conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(ips[0].String(), port))
+    if err != nil {
+        return nil, fmt.Errorf("tcp ping %s: %w", target, err)
+    }
+    defer conn.Close()
+    return tcpPingResult{LatencyMS: time.Since(start).Milliseconds()}, nil
+}
+
+// dnsLookupResult holds the resolved addresses for a hostname.
+type dnsLookupResult struct {
+    Addrs []string `json:"addrs"`
+}
+
+// dnsLookupTask resolves target to its IP addresses.
+func dnsLookupTask(ctx context.Context, target string) (any, error) {
+    addrs, err := net.DefaultResolver.LookupHost(ctx, target)
+    if err != nil {
+        return nil, fmt.Errorf("dns lookup %s: %w", target, err)
+    }
+    return dnsLookupResult{Addrs: addrs}, nil
+}
+
+// httpProbeResult reports the outcome of an HTTP GET against target.
+type httpProbeResult struct {
+    StatusCode int   `json:"status_code"`
+    LatencyMS  int64 `json:"latency_ms"`
+}
+
+// guardedHTTPClient re-validates the destination address at dial time
+// (not just against the URL string) so a DNS answer that changes between
+// validation and connection can't be used to reach guarded address space.
+var guardedHTTPClient = &http.Client{
+    Transport: &http.Transport{
+        DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+            host, port, err := net.SplitHostPort(addr)
+            if err != nil {
+                return nil, err
+            }
+            ips, err := resolveAndGuard(ctx, host)
+            if err != nil {
+                return nil, err
+            }
+            var d net.Dialer
+            return d.DialContext(ctx, network, net.JoinHostPort(ips[0].String(), port))
+        },
+    },
+}
+
+// httpProbeTask issues an HTTP GET to target (a full URL) and measures
+// the time to receive response headers. Only http/https are allowed and
+// the resolved address is validated against internal/private address
+// space, since this handler is reachable from the unauthenticated public
+// listener and must not double as an SSRF vector against internal
+// services or the cloud metadata endpoint.
+func httpProbeTask(ctx context.Context, target string) (any, error) {
+    parsed, err := url.Parse(target)
+    if err != nil {
+        return nil, fmt.Errorf("http probe %s: %w", target, err)
+    }
+    if parsed.Scheme != "http" && parsed.Scheme != "https" {
+        return nil, fmt.Errorf("http probe %s: scheme must be http or https", target)
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+    if err != nil {
+        return nil, fmt.Errorf("http probe %s: %w", target, err)
+    }
+
+    start := time.Now()
+    resp, err := guardedHTTPClient.Do(req)
+    if err != nil {
+        return nil, fmt.Errorf("http probe %s: %w", target, err)
+    }
+    defer resp.Body.Close()
+
+    return httpProbeResult{
+        StatusCode: resp.StatusCode,
+        LatencyMS:  time.Since(start).Milliseconds(),
+    }, nil
+}
+
+// tracerouteResult reports the number of hops traceroute reported.
+type tracerouteResult struct {
+    Hops int `json:"hops"`
+}
+
+// tracerouteTask shells out to the system traceroute binary and counts
+// the reported hops. It requires traceroute to be installed and
+// reachable on PATH; it returns an error otherwise. target is validated
+// as a real hostname/IP before being passed to exec.Command so a value
+// such as "-oSomething" can't be used to inject flags into the
+// invocation, and the address it resolves to is guarded the same way as
+// the other networking tasks.
+func tracerouteTask(ctx context.Context, target string) (any, error) {
+    if !looksLikeHostOrIP(target) {
+        return nil, fmt.Errorf("traceroute %s: invalid target", target)
+    }
+    if _, err := resolveAndGuard(ctx, target); err != nil {
+        return nil, err
+    }
+
+    cmd := exec.CommandContext(ctx, "traceroute", "-n", "--", target)
+    var out bytes.Buffer
+    cmd.Stdout = &out
+    if err := cmd.Run(); err != nil {
+        return nil, fmt.Errorf("traceroute %s: %w", target, err)
+    }
+
+    hops := 0
+    scanner := bufio.NewScanner(&out)
+    for scanner.Scan() {
+        hops++
+    }
+    if hops > 0 {
+        hops-- // first line is the traceroute banner, not a hop
+    }
+    return tracerouteResult{Hops: hops}, nil
+}